@@ -0,0 +1,96 @@
+package converter
+
+import "encoding/json"
+
+// Row is the resolved schema for a single Statement of Reasons record.
+// Field order matches the DSA transparency database CSV export. Enum-like
+// columns carry the "dict" tag so parquet-go dictionary-encodes them
+// instead of repeating the raw string per row, and "zstd" selects the
+// column compression codec.
+type Row struct {
+	UUID                       string `parquet:"uuid"`
+	DecisionVisibility         string `parquet:"decision_visibility,dict,zstd"`
+	DecisionVisibilityOther    string `parquet:"decision_visibility_other,optional,zstd"`
+	DecisionMonetary           string `parquet:"decision_monetary,dict,zstd"`
+	DecisionMonetaryOther      string `parquet:"decision_monetary_other,optional,zstd"`
+	DecisionProvisionRemoved   bool   `parquet:"decision_provision_removed"`
+	DecisionGround             string `parquet:"decision_ground,dict,zstd"`
+	DecisionGroundReferenceURL string `parquet:"decision_ground_reference_url,optional,zstd"`
+	IncompatibleContentIllegal bool   `parquet:"incompatible_content_illegal"`
+	Category                   string `parquet:"category,dict,zstd"`
+	CategorySpecification      string `parquet:"category_specification,dict,zstd"`
+	CategorySpecificationOther string `parquet:"category_specification_other,optional,zstd"`
+	ContentType                string `parquet:"content_type,dict,zstd"`
+	ContentTypeOther           string `parquet:"content_type_other,optional,zstd"`
+	ContentLanguage            string `parquet:"content_language,dict,zstd"`
+	ContentDate                string `parquet:"content_date,optional"`
+	TerritorialScope           string `parquet:"territorial_scope,dict,zstd"`
+	ApplicationDate            string `parquet:"application_date"`
+	SourceType                 string `parquet:"source_type,dict,zstd"`
+	AutomatedDetection         bool   `parquet:"automated_detection"`
+	AutomatedDecision          string `parquet:"automated_decision,dict,zstd"`
+	PlatformName               string `parquet:"platform_name,dict,zstd"`
+	PlatformUID                string `parquet:"platform_uid,optional"`
+}
+
+// ColumnDoc describes one resolved Parquet column for schema.json.
+type ColumnDoc struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Dictionary  bool   `json:"dictionary_encoded"`
+	Compression string `json:"compression"`
+}
+
+// SchemaDoc is the companion schema.json emitted alongside the converted
+// Parquet partitions, describing the column layout actually written.
+type SchemaDoc struct {
+	PartitionedBy []string    `json:"partitioned_by"`
+	Columns       []ColumnDoc `json:"columns"`
+}
+
+// BuildSchemaDoc describes the Row schema above. It is hand-maintained
+// rather than reflected, since the dict/zstd annotations are part of the
+// contract downstream consumers read schema.json for.
+func BuildSchemaDoc() SchemaDoc {
+	dictZstd := func(name string) ColumnDoc {
+		return ColumnDoc{Name: name, Type: "BYTE_ARRAY (UTF8)", Dictionary: true, Compression: "zstd"}
+	}
+	plain := func(name, typ string) ColumnDoc {
+		return ColumnDoc{Name: name, Type: typ, Dictionary: false, Compression: "zstd"}
+	}
+
+	return SchemaDoc{
+		PartitionedBy: []string{"platform", "date", "variant"},
+		Columns: []ColumnDoc{
+			plain("uuid", "BYTE_ARRAY (UTF8)"),
+			dictZstd("decision_visibility"),
+			plain("decision_visibility_other", "BYTE_ARRAY (UTF8)"),
+			dictZstd("decision_monetary"),
+			plain("decision_monetary_other", "BYTE_ARRAY (UTF8)"),
+			plain("decision_provision_removed", "BOOLEAN"),
+			dictZstd("decision_ground"),
+			plain("decision_ground_reference_url", "BYTE_ARRAY (UTF8)"),
+			plain("incompatible_content_illegal", "BOOLEAN"),
+			dictZstd("category"),
+			dictZstd("category_specification"),
+			plain("category_specification_other", "BYTE_ARRAY (UTF8)"),
+			dictZstd("content_type"),
+			plain("content_type_other", "BYTE_ARRAY (UTF8)"),
+			dictZstd("content_language"),
+			plain("content_date", "BYTE_ARRAY (UTF8)"),
+			dictZstd("territorial_scope"),
+			plain("application_date", "BYTE_ARRAY (UTF8)"),
+			dictZstd("source_type"),
+			plain("automated_detection", "BOOLEAN"),
+			dictZstd("automated_decision"),
+			dictZstd("platform_name"),
+			plain("platform_uid", "BYTE_ARRAY (UTF8)"),
+		},
+	}
+}
+
+// MarshalIndent renders the schema doc the same way the rest of the module
+// renders its JSON exports.
+func (s SchemaDoc) MarshalIndent() ([]byte, error) {
+	return json.MarshalIndent(s, "", "  ")
+}
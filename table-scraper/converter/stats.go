@@ -0,0 +1,84 @@
+package converter
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// StatsFile is the persisted, cumulative record of every day this module
+// has converted, used to populate Summary with measured Parquet sizes
+// instead of an estimate.
+type StatsFile struct {
+	Days map[string]DayStats `json:"days"`
+}
+
+// LoadStatsFile reads the stats file at path, returning an empty one if it
+// does not exist yet.
+func LoadStatsFile(path string) (*StatsFile, error) {
+	sf := &StatsFile{Days: map[string]DayStats{}}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return sf, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, sf); err != nil {
+		return nil, err
+	}
+	if sf.Days == nil {
+		sf.Days = map[string]DayStats{}
+	}
+	return sf, nil
+}
+
+// Put records (or replaces) the stats for a converted day, keyed by
+// date and variant so a full and a light archive for the same day don't
+// overwrite each other.
+func (sf *StatsFile) Put(stats DayStats) {
+	sf.Days[stats.Date+"_"+stats.Variant] = stats
+}
+
+// Save writes the stats file to path as indented JSON.
+func (sf *StatsFile) Save(path string) error {
+	data, err := json.MarshalIndent(sf, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// TotalParquetBytes sums the measured Parquet size across every platform
+// and day in the stats file.
+func (sf *StatsFile) TotalParquetBytes() int64 {
+	var total int64
+	for _, day := range sf.Days {
+		for _, p := range day.Platforms {
+			total += p.ParquetBytes
+		}
+	}
+	return total
+}
+
+// TotalCSVBytes sums the measured CSV size across every day in the stats
+// file.
+func (sf *StatsFile) TotalCSVBytes() int64 {
+	var total int64
+	for _, day := range sf.Days {
+		total += day.CSVBytes
+	}
+	return total
+}
+
+// ParquetBytesByPlatform sums measured Parquet size per platform across
+// every converted day.
+func (sf *StatsFile) ParquetBytesByPlatform() map[string]int64 {
+	totals := map[string]int64{}
+	for _, day := range sf.Days {
+		for platform, p := range day.Platforms {
+			totals[platform] += p.ParquetBytes
+		}
+	}
+	return totals
+}
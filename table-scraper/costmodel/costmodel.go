@@ -0,0 +1,158 @@
+// Package costmodel estimates monthly storage, request, and egress costs
+// across multiple cloud providers and storage tiers, replacing a single
+// hard-coded AWS Standard/Deep-Archive comparison with a pluggable model
+// that reads list prices from an embedded YAML sheet.
+package costmodel
+
+import "fmt"
+
+// Request describes the workload to estimate a cost for.
+type Request struct {
+	// Region overrides the provider's DefaultRegion, if set.
+	Region string
+	// SizeTB is the size of the dataset in this tier.
+	SizeTB float64
+	// MonthlyPuts is the number of PUT/upload requests per month (one per
+	// daily archive is the module's typical workload).
+	MonthlyPuts int64
+	// MonthlyGets is the number of GET/download requests per month.
+	MonthlyGets int64
+}
+
+// Estimate is one provider/tier/region cost projection.
+type Estimate struct {
+	Provider        string  `json:"provider"`
+	Tier            string  `json:"tier"`
+	Region          string  `json:"region"`
+	MonthlyStorage  float64 `json:"monthly_storage_usd"`
+	MonthlyPutCost  float64 `json:"monthly_put_cost_usd"`
+	MonthlyGetCost  float64 `json:"monthly_get_cost_usd"`
+	AnnualEgress1x  float64 `json:"annual_egress_1x_usd"`
+	AnnualEgress12x float64 `json:"annual_egress_12x_usd"`
+}
+
+// Provider is a cloud storage provider exposing one or more storage tiers.
+type Provider interface {
+	// Key identifies the provider in the price sheet, e.g. "aws_s3".
+	Key() string
+	// DisplayName is a human-readable name, e.g. "AWS S3".
+	DisplayName() string
+	// DefaultRegion is used when a Request doesn't override Region.
+	DefaultRegion() string
+	// Tiers lists the storage tier keys this provider supports, e.g.
+	// "standard", "glacier_instant_retrieval".
+	Tiers() []string
+	// Estimate projects the cost of storing and accessing req in tier.
+	Estimate(tier string, req Request) (Estimate, error)
+}
+
+// provider is the shared Provider implementation; every built-in provider
+// is just one of these with a fixed key/name/region/tier list.
+type provider struct {
+	key           string
+	displayName   string
+	defaultRegion string
+	tiers         []string
+	sheet         *PriceSheet
+}
+
+func (p *provider) Key() string           { return p.key }
+func (p *provider) DisplayName() string   { return p.displayName }
+func (p *provider) DefaultRegion() string { return p.defaultRegion }
+func (p *provider) Tiers() []string       { return p.tiers }
+
+func (p *provider) Estimate(tier string, req Request) (Estimate, error) {
+	region := req.Region
+	if region == "" {
+		region = p.defaultRegion
+	}
+
+	rates, egressPerGB, err := p.sheet.rates(p.key, region, tier)
+	if err != nil {
+		return Estimate{}, err
+	}
+
+	const tbToGB = 1000.0
+	sizeGB := req.SizeTB * tbToGB
+
+	return Estimate{
+		Provider:        p.displayName,
+		Tier:            tier,
+		Region:          region,
+		MonthlyStorage:  sizeGB * rates.StoragePerGBMonth,
+		MonthlyPutCost:  float64(req.MonthlyPuts) / 1000 * rates.PutPer1000,
+		MonthlyGetCost:  float64(req.MonthlyGets) / 1000 * rates.GetPer1000,
+		AnnualEgress1x:  sizeGB * egressPerGB,
+		AnnualEgress12x: sizeGB * 12 * egressPerGB,
+	}, nil
+}
+
+// AWSS3 returns the AWS S3 provider (Standard, IA, Glacier Instant
+// Retrieval, Glacier Flexible Retrieval, Deep Archive), priced for
+// eu-central-1.
+func AWSS3() Provider {
+	return &provider{
+		key:           "aws_s3",
+		displayName:   "AWS S3",
+		defaultRegion: "eu-central-1",
+		tiers:         []string{"standard", "infrequent_access", "glacier_instant_retrieval", "glacier_flexible_retrieval", "deep_archive"},
+		sheet:         defaultPriceSheet,
+	}
+}
+
+// GCS returns the Google Cloud Storage provider (Standard, Nearline,
+// Coldline, Archive), priced for europe-west1.
+func GCS() Provider {
+	return &provider{
+		key:           "gcs",
+		displayName:   "Google Cloud Storage",
+		defaultRegion: "europe-west1",
+		tiers:         []string{"standard", "nearline", "coldline", "archive"},
+		sheet:         defaultPriceSheet,
+	}
+}
+
+// AzureBlob returns the Azure Blob Storage provider (Hot, Cool, Archive),
+// priced for westeurope.
+func AzureBlob() Provider {
+	return &provider{
+		key:           "azure_blob",
+		displayName:   "Azure Blob Storage",
+		defaultRegion: "westeurope",
+		tiers:         []string{"hot", "cool", "archive"},
+		sheet:         defaultPriceSheet,
+	}
+}
+
+// BackblazeB2 returns the Backblaze B2 provider, which only offers a
+// single storage class.
+func BackblazeB2() Provider {
+	return &provider{
+		key:           "backblaze_b2",
+		displayName:   "Backblaze B2",
+		defaultRegion: "us-west",
+		tiers:         []string{"standard"},
+		sheet:         defaultPriceSheet,
+	}
+}
+
+// AllProviders returns every built-in Provider.
+func AllProviders() []Provider {
+	return []Provider{AWSS3(), GCS(), AzureBlob(), BackblazeB2()}
+}
+
+// ComparisonMatrix estimates req against every tier of every provider, so
+// callers can pick the cheapest option for their access pattern.
+func ComparisonMatrix(providers []Provider, req Request) ([]Estimate, error) {
+	var matrix []Estimate
+	for _, p := range providers {
+		for _, tier := range p.Tiers() {
+			est, err := p.Estimate(tier, req)
+			if err != nil {
+				return nil, fmt.Errorf("estimating %s/%s: %v", p.Key(), tier, err)
+			}
+			matrix = append(matrix, est)
+		}
+	}
+	return matrix, nil
+}
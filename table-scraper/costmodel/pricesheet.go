@@ -0,0 +1,65 @@
+package costmodel
+
+import (
+	_ "embed"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed prices.yaml
+var pricesYAML []byte
+
+// TierRates are the per-unit costs for one storage tier in one region.
+type TierRates struct {
+	StoragePerGBMonth float64 `yaml:"storage_per_gb_month"`
+	PutPer1000        float64 `yaml:"put_per_1000"`
+	GetPer1000        float64 `yaml:"get_per_1000"`
+}
+
+// RegionRates is a region's egress rate plus its per-tier rates.
+type RegionRates struct {
+	EgressPerGB float64              `yaml:"egress_per_gb"`
+	Tiers       map[string]TierRates `yaml:"tiers"`
+}
+
+// ProviderRates is a provider's rates, keyed by region.
+type ProviderRates struct {
+	Regions map[string]RegionRates `yaml:"regions"`
+}
+
+// PriceSheet is the parsed form of the embedded prices.yaml. It is the
+// single place this module's cost estimates read list prices from.
+type PriceSheet struct {
+	Providers map[string]ProviderRates `yaml:"providers"`
+}
+
+// loadPriceSheet parses the embedded price sheet once at package init.
+func loadPriceSheet() *PriceSheet {
+	var sheet PriceSheet
+	if err := yaml.Unmarshal(pricesYAML, &sheet); err != nil {
+		panic(fmt.Sprintf("costmodel: embedded prices.yaml is invalid: %v", err))
+	}
+	return &sheet
+}
+
+var defaultPriceSheet = loadPriceSheet()
+
+func (s *PriceSheet) rates(providerKey, region, tier string) (TierRates, float64, error) {
+	provider, ok := s.Providers[providerKey]
+	if !ok {
+		return TierRates{}, 0, fmt.Errorf("costmodel: unknown provider %q", providerKey)
+	}
+
+	regionRates, ok := provider.Regions[region]
+	if !ok {
+		return TierRates{}, 0, fmt.Errorf("costmodel: provider %q has no rates for region %q", providerKey, region)
+	}
+
+	tierRates, ok := regionRates.Tiers[tier]
+	if !ok {
+		return TierRates{}, 0, fmt.Errorf("costmodel: provider %q region %q has no tier %q", providerKey, region, tier)
+	}
+
+	return tierRates, regionRates.EgressPerGB, nil
+}
@@ -0,0 +1,283 @@
+// Package downloader fetches the ZIP archives a scrape discovers, verifying
+// each one against its published SHA1 checksum and resuming partial
+// downloads across runs.
+package downloader
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RemoteFile is a single archive to fetch. Variant is either "full" or
+// "light" and is used for --only filtering and for building the local file
+// name.
+type RemoteFile struct {
+	Date    string
+	Variant string
+	ZipURL  string
+	Sha1URL string
+}
+
+// Config controls a download run.
+type Config struct {
+	OutDir       string
+	S3Prefix     string
+	Workers      int
+	ManifestPath string
+	Only         Filter
+	MaxRetries   int
+}
+
+// Result reports what happened to a single RemoteFile.
+type Result struct {
+	File    RemoteFile
+	Path    string
+	Skipped bool
+	Err     error
+}
+
+// Downloader runs parallel, resumable, checksum-verified downloads of
+// RemoteFiles against a Config.
+type Downloader struct {
+	cfg      Config
+	client   *http.Client
+	manifest *Manifest
+	mu       sync.Mutex
+}
+
+// New builds a Downloader, loading (or creating) its manifest from
+// cfg.ManifestPath.
+func New(cfg Config) (*Downloader, error) {
+	if cfg.Workers <= 0 {
+		cfg.Workers = 4
+	}
+	if cfg.MaxRetries <= 0 {
+		cfg.MaxRetries = 5
+	}
+	if cfg.ManifestPath == "" {
+		cfg.ManifestPath = filepath.Join(cfg.OutDir, "manifest.json")
+	}
+
+	manifest, err := LoadManifest(cfg.ManifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("loading manifest: %v", err)
+	}
+
+	return &Downloader{
+		cfg:      cfg,
+		client:   &http.Client{Timeout: 10 * time.Minute},
+		manifest: manifest,
+	}, nil
+}
+
+// Run downloads every file that passes cfg.Only, skipping files the
+// manifest already has a verified checksum for. It fans work out across
+// cfg.Workers goroutines and returns one Result per file that was
+// considered.
+func (d *Downloader) Run(ctx context.Context, files []RemoteFile) ([]Result, error) {
+	if err := os.MkdirAll(d.cfg.OutDir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating out dir: %v", err)
+	}
+
+	jobs := make(chan RemoteFile)
+	results := make([]Result, 0, len(files))
+	var resultsMu sync.Mutex
+
+	var wg sync.WaitGroup
+	for i := 0; i < d.cfg.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for f := range jobs {
+				res := d.downloadOne(ctx, f)
+				resultsMu.Lock()
+				results = append(results, res)
+				resultsMu.Unlock()
+			}
+		}()
+	}
+
+feed:
+	for _, f := range files {
+		if !d.cfg.Only.Matches(f) {
+			continue
+		}
+		select {
+		case jobs <- f:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := d.manifest.Save(d.cfg.ManifestPath); err != nil {
+		return results, fmt.Errorf("saving manifest: %v", err)
+	}
+
+	return results, ctx.Err()
+}
+
+func (d *Downloader) downloadOne(ctx context.Context, f RemoteFile) Result {
+	localPath := filepath.Join(d.cfg.OutDir, localFileName(f))
+
+	if entry, ok := d.manifest.Get(f.ZipURL); ok && entry.Verified {
+		if info, err := os.Stat(localPath); err == nil && info.Size() == entry.Size {
+			return Result{File: f, Path: localPath, Skipped: true}
+		}
+	}
+
+	if err := d.fetchWithResume(ctx, f.ZipURL, localPath); err != nil {
+		return Result{File: f, Err: fmt.Errorf("downloading %s: %v", f.ZipURL, err)}
+	}
+
+	sum, size, err := sha1File(localPath)
+	if err != nil {
+		return Result{File: f, Err: fmt.Errorf("hashing %s: %v", localPath, err)}
+	}
+
+	want, err := d.fetchSha1(ctx, f.Sha1URL)
+	if err != nil {
+		return Result{File: f, Err: fmt.Errorf("fetching sha1 for %s: %v", f.ZipURL, err)}
+	}
+
+	if !strings.EqualFold(sum, want) {
+		return Result{File: f, Err: fmt.Errorf("checksum mismatch for %s: got %s want %s", localPath, sum, want)}
+	}
+
+	if d.cfg.S3Prefix != "" {
+		if err := uploadToS3(ctx, localPath, d.cfg.S3Prefix, f); err != nil {
+			return Result{File: f, Err: fmt.Errorf("uploading %s to s3: %v", localPath, err)}
+		}
+	}
+
+	d.mu.Lock()
+	d.manifest.Put(f.ZipURL, ManifestEntry{
+		SHA1:       sum,
+		Size:       size,
+		Verified:   true,
+		VerifiedAt: time.Now().UTC().Format(time.RFC3339),
+	})
+	d.mu.Unlock()
+
+	return Result{File: f, Path: localPath}
+}
+
+// fetchWithResume downloads url to dest, resuming from dest's current size
+// via an HTTP Range request if a partial file is already present.
+func (d *Downloader) fetchWithResume(ctx context.Context, url, dest string) error {
+	return withRetry(d.cfg.MaxRetries, func() error {
+		// Re-stat on every attempt: a failed io.Copy on a prior attempt may
+		// have left a longer partial file on disk, so the Range offset
+		// must track the file's actual current size, not a value computed
+		// once before the retry loop started.
+		var offset int64
+		if info, err := os.Stat(dest); err == nil {
+			offset = info.Size()
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		if offset > 0 {
+			req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		}
+
+		resp, err := d.client.Do(req)
+		if err != nil {
+			return retryable(err)
+		}
+		defer resp.Body.Close()
+
+		flags := os.O_CREATE | os.O_WRONLY
+		switch resp.StatusCode {
+		case http.StatusOK:
+			flags |= os.O_TRUNC
+		case http.StatusPartialContent:
+			flags |= os.O_APPEND
+		case http.StatusRequestedRangeNotSatisfiable:
+			// Already fully downloaded.
+			return nil
+		default:
+			if resp.StatusCode >= 500 {
+				return retryable(fmt.Errorf("server error %d", resp.StatusCode))
+			}
+			return fmt.Errorf("unexpected status %d", resp.StatusCode)
+		}
+
+		f, err := os.OpenFile(dest, flags, 0o644)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		if _, err := io.Copy(f, resp.Body); err != nil {
+			return retryable(err)
+		}
+		return nil
+	})
+}
+
+func (d *Downloader) fetchSha1(ctx context.Context, url string) (string, error) {
+	var sum string
+	err := withRetry(d.cfg.MaxRetries, func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := d.client.Do(req)
+		if err != nil {
+			return retryable(err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode >= 500 {
+			return retryable(fmt.Errorf("server error %d", resp.StatusCode))
+		}
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("unexpected status %d", resp.StatusCode)
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return retryable(err)
+		}
+		// The DSA portal publishes sha1 files as "<hex>  <filename>".
+		fields := strings.Fields(strings.TrimSpace(string(body)))
+		if len(fields) == 0 {
+			return fmt.Errorf("empty sha1 response from %s", url)
+		}
+		sum = strings.ToLower(fields[0])
+		return nil
+	})
+	return sum, err
+}
+
+func sha1File(path string) (sum string, size int64, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+
+	h := sha1.New()
+	size, err = io.Copy(h, f)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), size, nil
+}
+
+func localFileName(f RemoteFile) string {
+	return fmt.Sprintf("%s_%s.zip", f.Date, f.Variant)
+}
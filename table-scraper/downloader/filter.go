@@ -0,0 +1,54 @@
+package downloader
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Filter narrows which RemoteFiles a Downloader.Run call considers, driven
+// by the CLI's --only flag.
+type Filter struct {
+	From    string // inclusive, YYYY-MM-DD, empty means unbounded
+	To      string // inclusive, YYYY-MM-DD, empty means unbounded
+	Variant string // "full", "light", or "" for both
+}
+
+// Matches reports whether f should be downloaded under this filter.
+func (flt Filter) Matches(f RemoteFile) bool {
+	if flt.From != "" && f.Date < flt.From {
+		return false
+	}
+	if flt.To != "" && f.Date > flt.To {
+		return false
+	}
+	if flt.Variant != "" && flt.Variant != f.Variant {
+		return false
+	}
+	return true
+}
+
+// ParseFilter parses the --only flag value, e.g. "2024-01-01:2024-02-01,full"
+// or "full" or "2024-01-01:" or "". An empty string matches everything.
+func ParseFilter(spec string) (Filter, error) {
+	var flt Filter
+	if spec == "" {
+		return flt, nil
+	}
+
+	for _, part := range strings.Split(spec, ",") {
+		switch part {
+		case "full", "light":
+			if flt.Variant != "" {
+				return Filter{}, fmt.Errorf("--only: variant specified twice")
+			}
+			flt.Variant = part
+		default:
+			if !strings.Contains(part, ":") {
+				return Filter{}, fmt.Errorf("--only: unrecognized term %q", part)
+			}
+			bounds := strings.SplitN(part, ":", 2)
+			flt.From, flt.To = bounds[0], bounds[1]
+		}
+	}
+	return flt, nil
+}
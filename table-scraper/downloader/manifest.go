@@ -0,0 +1,71 @@
+package downloader
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+// ManifestEntry records what we know about a single downloaded URL so
+// re-running a download skips files that are already verified.
+type ManifestEntry struct {
+	SHA1       string `json:"sha1"`
+	Size       int64  `json:"size"`
+	Verified   bool   `json:"verified"`
+	VerifiedAt string `json:"verified_at"`
+}
+
+// Manifest is the on-disk record of every file a downloader run has
+// verified, keyed by the archive's zip URL.
+type Manifest struct {
+	mu      sync.RWMutex
+	Entries map[string]ManifestEntry `json:"entries"`
+}
+
+// LoadManifest reads the manifest at path, returning an empty manifest if
+// the file does not exist yet.
+func LoadManifest(path string) (*Manifest, error) {
+	m := &Manifest{Entries: map[string]ManifestEntry{}}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+	if m.Entries == nil {
+		m.Entries = map[string]ManifestEntry{}
+	}
+	return m, nil
+}
+
+// Get returns the manifest entry for url, if any.
+func (m *Manifest) Get(url string) (ManifestEntry, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	e, ok := m.Entries[url]
+	return e, ok
+}
+
+// Put records or replaces the manifest entry for url.
+func (m *Manifest) Put(url string, entry ManifestEntry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Entries[url] = entry
+}
+
+// Save writes the manifest to path as indented JSON.
+func (m *Manifest) Save(path string) error {
+	m.mu.RLock()
+	data, err := json.MarshalIndent(m, "", "  ")
+	m.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
@@ -0,0 +1,54 @@
+package downloader
+
+import (
+	"errors"
+	"time"
+)
+
+// retryableError marks an error as transient. withRetry only backs off and
+// retries errors wrapped this way; anything else (e.g. a permanent 404) is
+// returned to the caller immediately instead of being retried maxAttempts
+// times with backoff.
+type retryableError struct {
+	err error
+}
+
+func (r *retryableError) Error() string { return r.err.Error() }
+func (r *retryableError) Unwrap() error { return r.err }
+
+// retryable wraps err so withRetry treats it as a transient 5xx /
+// connection-reset style failure worth retrying.
+func retryable(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &retryableError{err: err}
+}
+
+// withRetry calls fn up to maxAttempts times, backing off exponentially
+// (1s, 2s, 4s, ...) between attempts. Only errors fn wraps with retryable
+// are retried; any other error returns immediately.
+func withRetry(maxAttempts int, fn func() error) error {
+	var err error
+	backoff := time.Second
+
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		err = fn()
+		if err == nil {
+			return nil
+		}
+
+		var re *retryableError
+		if !errors.As(err, &re) {
+			return err
+		}
+		err = re.err
+
+		if attempt == maxAttempts {
+			break
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+	return err
+}
@@ -0,0 +1,61 @@
+package downloader
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// uploadToS3 pushes the already-verified file at localPath to
+// <prefix>/<date>_<variant>.zip, where prefix is an "s3://bucket/key/path"
+// URL.
+func uploadToS3(ctx context.Context, localPath, prefix string, f RemoteFile) error {
+	bucket, keyPrefix, err := parseS3Prefix(prefix)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("loading AWS config: %v", err)
+	}
+
+	file, err := os.Open(localPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	key := strings.TrimSuffix(keyPrefix, "/") + "/" + localFileName(f)
+
+	client := s3.NewFromConfig(cfg)
+	_, err = client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+		Body:   file,
+	})
+	return err
+}
+
+func parseS3Prefix(prefix string) (bucket, key string, err error) {
+	const schema = "s3://"
+	if !strings.HasPrefix(prefix, schema) {
+		return "", "", fmt.Errorf("s3 prefix %q must start with %q", prefix, schema)
+	}
+
+	rest := strings.TrimPrefix(prefix, schema)
+	parts := strings.SplitN(rest, "/", 2)
+	bucket = parts[0]
+	if len(parts) == 2 {
+		key = parts[1]
+	}
+	if bucket == "" {
+		return "", "", fmt.Errorf("s3 prefix %q is missing a bucket name", prefix)
+	}
+	return bucket, key, nil
+}
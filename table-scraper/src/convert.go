@@ -0,0 +1,133 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/o2ba/dsa-data-analysis/table-scraper/converter"
+)
+
+// runConvert implements the `convert` subcommand: turn every downloaded
+// archive ZIP in --in into partitioned, ZSTD-compressed Parquet under
+// --out, and optionally overlay the measured sizes onto an existing
+// DataExport JSON so Summary stops guessing.
+func runConvert(args []string) {
+	fs := flag.NewFlagSet("convert", flag.ExitOnError)
+	inDir := fs.String("in", "data/archives", "directory of downloaded archive ZIPs")
+	outDir := fs.String("out", "data/parquet", "directory to write partitioned Parquet into")
+	statsPath := fs.String("stats", "", "path to the cumulative conversion stats file (defaults to <out>/parquet_stats.json)")
+	exportPath := fs.String("export", "", "DataExport JSON to overlay measured Parquet sizes onto (optional)")
+	if err := fs.Parse(args); err != nil {
+		log.Fatal(err)
+	}
+	if *statsPath == "" {
+		*statsPath = filepath.Join(*outDir, "parquet_stats.json")
+	}
+
+	if err := os.MkdirAll(*outDir, 0o755); err != nil {
+		log.Fatal(err)
+	}
+
+	sf, err := converter.LoadStatsFile(*statsPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var zips []string
+	for _, variant := range []string{"full", "light"} {
+		matches, err := filepath.Glob(filepath.Join(*inDir, "*_"+variant+".zip"))
+		if err != nil {
+			log.Fatal(err)
+		}
+		zips = append(zips, matches...)
+	}
+
+	for _, zipPath := range zips {
+		base := filepath.Base(zipPath)
+		variant := "full"
+		if strings.HasSuffix(base, "_light.zip") {
+			variant = "light"
+		}
+		date := strings.TrimSuffix(base, "_"+variant+".zip")
+
+		stats, err := converter.Convert(zipPath, date, variant, *outDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "FAILED %s: %v\n", zipPath, err)
+			continue
+		}
+
+		sf.Put(stats)
+		fmt.Printf("OK     %s (%s): %d rows across %d platform(s)\n", date, variant, rowCount(stats), len(stats.Platforms))
+	}
+
+	if err := sf.Save(*statsPath); err != nil {
+		log.Fatal(err)
+	}
+
+	schemaDoc := converter.BuildSchemaDoc()
+	schemaJSON, err := schemaDoc.MarshalIndent()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(*outDir, "schema.json"), schemaJSON, 0o644); err != nil {
+		log.Fatal(err)
+	}
+
+	if *exportPath != "" {
+		if err := overlayMeasuredSizes(*exportPath, sf); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	fmt.Printf("Stats file holds %d converted archive(s), %d bytes of Parquet total\n", len(sf.Days), sf.TotalParquetBytes())
+}
+
+func rowCount(stats converter.DayStats) int64 {
+	var n int64
+	for _, p := range stats.Platforms {
+		n += p.Rows
+	}
+	return n
+}
+
+// overlayMeasuredSizes rewrites exportPath's EveryDay summary to use the
+// measured totals in sf instead of the parquetReductionFactor* guess.
+func overlayMeasuredSizes(exportPath string, sf *converter.StatsFile) error {
+	data, err := os.ReadFile(exportPath)
+	if err != nil {
+		return fmt.Errorf("reading %s: %v", exportPath, err)
+	}
+
+	var export DataExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return fmt.Errorf("parsing %s: %v", exportPath, err)
+	}
+
+	// The TotalFull* fields describe the full archives only, so light
+	// archives' Parquet bytes are left out of them.
+	var fullParquetBytes int64
+	for _, day := range sf.Days {
+		if day.Variant != "full" {
+			continue
+		}
+		for _, p := range day.Platforms {
+			fullParquetBytes += p.ParquetBytes
+		}
+	}
+	measuredTB := mbToTb(float64(fullParquetBytes) / 1024 / 1024)
+	export.Summaries.EveryDay.TotalFullParquetConservativeSize = measuredTB
+	export.Summaries.EveryDay.TotalFullParquetAggressiveSize = measuredTB
+	export.Summaries.EveryDay.ParquetSizeSource = "measured"
+	export.Summaries.EveryDay.MeasuredParquetBytesByPlatform = sf.ParquetBytesByPlatform()
+
+	out, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(exportPath, out, 0o644)
+}
@@ -0,0 +1,15 @@
+package main
+
+import "github.com/o2ba/dsa-data-analysis/table-scraper/costmodel"
+
+// buildCostComparison projects storage, request, and egress costs for the
+// EveryDay summary's full CSV size across every built-in costmodel
+// provider and tier.
+func buildCostComparison(everyDay Summary, monthlyPuts, monthlyGets int64) ([]costmodel.Estimate, error) {
+	req := costmodel.Request{
+		SizeTB:      everyDay.TotalFullCSVSizeTB,
+		MonthlyPuts: monthlyPuts,
+		MonthlyGets: monthlyGets,
+	}
+	return costmodel.ComparisonMatrix(costmodel.AllProviders(), req)
+}
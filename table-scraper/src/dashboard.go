@@ -0,0 +1,25 @@
+package main
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+// dashboardAssets is the static HTML/CSS/JS for the simple read-only
+// dashboard served by `serve`. The page itself polls /healthz and /summary
+// via fetch(), so there's no server-side templating to do here.
+//
+//go:embed dashboard/*
+var dashboardAssets embed.FS
+
+// dashboardHandler serves dashboardAssets rooted at dashboard/, so
+// /index.html, /style.css, and /app.js resolve without the "dashboard/"
+// prefix.
+func dashboardHandler() (http.Handler, error) {
+	assets, err := fs.Sub(dashboardAssets, "dashboard")
+	if err != nil {
+		return nil, err
+	}
+	return http.FileServer(http.FS(assets)), nil
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/o2ba/dsa-data-analysis/table-scraper/downloader"
+)
+
+// runDownload implements the `download` subcommand: scrape the current
+// archive listing and fetch every Full/Light zip it references.
+func runDownload(args []string) {
+	fs := flag.NewFlagSet("download", flag.ExitOnError)
+	outDir := fs.String("out", "data/archives", "local directory to download archives into")
+	s3Prefix := fs.String("s3-prefix", "", "s3://bucket/prefix to upload verified archives to, in addition to --out")
+	workers := fs.Int("workers", 4, "number of parallel downloads")
+	only := fs.String("only", "", "filter, e.g. \"2024-01-01:2024-02-01,full\"")
+	manifest := fs.String("manifest", "", "path to the resumable manifest file (defaults to <out>/manifest.json)")
+	retries := fs.Int("retries", 5, "max attempts per file before giving up")
+	if err := fs.Parse(args); err != nil {
+		log.Fatal(err)
+	}
+
+	filter, err := downloader.ParseFilter(*only)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	records, err := scrapeData()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	d, err := downloader.New(downloader.Config{
+		OutDir:       *outDir,
+		S3Prefix:     *s3Prefix,
+		Workers:      *workers,
+		ManifestPath: *manifest,
+		Only:         filter,
+		MaxRetries:   *retries,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	results, err := d.Run(context.Background(), remoteFiles(records))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var failed, skipped int
+	for _, r := range results {
+		switch {
+		case r.Err != nil:
+			failed++
+			fmt.Fprintf(os.Stderr, "FAILED %s %s: %v\n", r.File.Date, r.File.Variant, r.Err)
+		case r.Skipped:
+			skipped++
+			fmt.Printf("SKIP   %s %s (already verified)\n", r.File.Date, r.File.Variant)
+		default:
+			fmt.Printf("OK     %s %s -> %s\n", r.File.Date, r.File.Variant, r.Path)
+		}
+	}
+
+	fmt.Printf("Downloaded %d files, %d skipped, %d failed\n", len(results)-failed-skipped, skipped, failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// remoteFiles flattens each DailyRecord's Full and Light archives into the
+// downloader's RemoteFile shape.
+func remoteFiles(records []DailyRecord) []downloader.RemoteFile {
+	files := make([]downloader.RemoteFile, 0, len(records)*2)
+	for _, r := range records {
+		if r.FullZipURL != "" {
+			files = append(files, downloader.RemoteFile{
+				Date:    r.Date,
+				Variant: "full",
+				ZipURL:  r.FullZipURL,
+				Sha1URL: r.FullSha1URL,
+			})
+		}
+		if r.LightZipURL != "" {
+			files = append(files, downloader.RemoteFile{
+				Date:    r.Date,
+				Variant: "light",
+				ZipURL:  r.LightZipURL,
+				Sha1URL: r.LightSha1URL,
+			})
+		}
+	}
+	return files
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/o2ba/dsa-data-analysis/table-scraper/statestore"
+)
+
+// Diff reports what changed in the archive listing since the last
+// incremental scrape.
+type Diff struct {
+	Added   []DailyRecord   `json:"added"`
+	Changed []ChangedRecord `json:"changed"`
+	Removed []DailyRecord   `json:"removed"`
+}
+
+// ChangedRecord pairs the previously-seen and current state of a record
+// whose row hash no longer matches the state store.
+type ChangedRecord struct {
+	Old DailyRecord `json:"old"`
+	New DailyRecord `json:"new"`
+}
+
+// scrapeDataIncremental behaves like scrapeData, except it consults store
+// for each page it reads and stops paginating as soon as it reaches a page
+// whose rows all already match their stored hash. Pages that weren't
+// re-visited are assumed unchanged, so Diff.Removed can only report
+// records within the date range this run actually scraped.
+func scrapeDataIncremental(store *statestore.Store) ([]DailyRecord, Diff, error) {
+	var allRecords []DailyRecord
+	var diff Diff
+	seen := map[string]bool{}
+	now := time.Now().UTC().Format(time.RFC3339)
+	page := 1
+
+	for {
+		url := fmt.Sprintf("%s%d", baseURL, page)
+		fmt.Printf("Scraping page %d: %s\n", page, url)
+
+		records, hasMore, err := scrapePage(url)
+		if err != nil {
+			return nil, diff, fmt.Errorf("error scraping page %d: %v", page, err)
+		}
+
+		pageUnchanged := true
+		for _, rec := range records {
+			key := recordKey(rec)
+			hash := rowHash(rec)
+			seen[key] = true
+
+			entry, found, err := store.Get(key)
+			if err != nil {
+				return nil, diff, fmt.Errorf("reading state store for %s: %v", key, err)
+			}
+			switch {
+			case !found:
+				diff.Added = append(diff.Added, rec)
+				pageUnchanged = false
+			case entry.RowHash != hash:
+				diff.Changed = append(diff.Changed, ChangedRecord{Old: entryToRecord(entry), New: rec})
+				pageUnchanged = false
+			}
+
+			if err := store.Put(key, statestore.Entry{
+				Date:        rec.Date,
+				FullSha1URL: rec.FullSha1URL,
+				RowHash:     hash,
+				LastSeenAt:  now,
+			}); err != nil {
+				return nil, diff, fmt.Errorf("updating state store for %s: %v", key, err)
+			}
+		}
+
+		allRecords = append(allRecords, records...)
+
+		if pageUnchanged && len(records) > 0 {
+			fmt.Printf("Page %d unchanged, stopping pagination\n", page)
+			break
+		}
+		if !hasMore {
+			break
+		}
+
+		page++
+		time.Sleep(time.Duration(scrapeDelay) * time.Millisecond)
+	}
+
+	if err := findRemoved(store, allRecords, seen, &diff); err != nil {
+		return nil, diff, err
+	}
+
+	return allRecords, diff, nil
+}
+
+// findRemoved reports store entries within the date range this run
+// scraped that weren't seen again, i.e. rows that disappeared from the
+// listing.
+func findRemoved(store *statestore.Store, records []DailyRecord, seen map[string]bool, diff *Diff) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	oldest := records[0].Date
+	for _, r := range records {
+		if r.Date < oldest {
+			oldest = r.Date
+		}
+	}
+
+	return store.ForEach(func(key string, entry statestore.Entry) error {
+		if entry.Date < oldest || seen[key] {
+			return nil
+		}
+		diff.Removed = append(diff.Removed, entryToRecord(entry))
+		return nil
+	})
+}
+
+// recordKey identifies a record by (date, full_sha1_url), matching how the
+// DSA portal actually re-issues archives: the same date can get a new
+// zip/sha1 pair if the day's export is regenerated.
+func recordKey(r DailyRecord) string {
+	return r.Date + "|" + r.FullSha1URL
+}
+
+// rowHash fingerprints every field of a record so changes that don't touch
+// the SHA1 URL (e.g. a corrected statement count) are still detected.
+func rowHash(r DailyRecord) string {
+	data, _ := json.Marshal(r)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// entryToRecord reconstructs the minimal DailyRecord shape we can recover
+// from a stored Entry, for diff reporting of records we no longer scrape
+// in full.
+func entryToRecord(e statestore.Entry) DailyRecord {
+	return DailyRecord{Date: e.Date, FullSha1URL: e.FullSha1URL}
+}
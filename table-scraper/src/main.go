@@ -2,24 +2,67 @@ package main
 
 import (
 	"encoding/json"
+	"flag"
 	"fmt"
 	"log"
 	"os"
+	"strings"
 	"time"
+
+	"github.com/o2ba/dsa-data-analysis/table-scraper/statestore"
 )
 
 const (
-	baseURL     = "https://transparency.dsa.ec.europa.eu/explore-data/download?page="
-	scrapeDelay = 500
-	// Technically for first 50 TB only, but for now max data size is still below
-	euCentralS3StroageStandardPerGB           = 0.0245
-	euCentralS3StroageGlacierDeepArchivePerGB = 0.00099
-	parquetReductionFactorConservative        = 0.2
-	parquetReductionFactorAggressive          = 0.1
+	baseURL                            = "https://transparency.dsa.ec.europa.eu/explore-data/download?page="
+	scrapeDelay                        = 500
+	parquetReductionFactorConservative = 0.2
+	parquetReductionFactorAggressive   = 0.1
 )
 
 func main() {
-	records, err := scrapeData()
+	// No subcommand given: preserve the original scrape-and-export behavior.
+	if len(os.Args) < 2 || strings.HasPrefix(os.Args[1], "-") {
+		runScrape()
+		return
+	}
+
+	switch os.Args[1] {
+	case "download":
+		runDownload(os.Args[2:])
+	case "convert":
+		runConvert(os.Args[2:])
+	case "serve":
+		runServe(os.Args[2:])
+	default:
+		runScrape()
+	}
+}
+
+func runScrape() {
+	incremental := flag.Bool("incremental", false, "only re-scrape pages that have changed since the last run, using --state as a fingerprint store")
+	statePath := flag.String("state", "data/state.db", "BoltDB file tracking last-seen SHA1 URL/row hash per record (incremental mode only)")
+	sampling := flag.String("sampling", "", "additional sampling strategy to compute alongside the fixed-interval summaries: fixed:N, reservoir:K, stratified, bootstrap:N")
+	monthlyPuts := flag.Int64("monthly-puts", 30, "assumed PUT requests per month (one per daily archive), for the cost comparison matrix")
+	monthlyGets := flag.Int64("monthly-gets", 30, "assumed GET requests per month, for the cost comparison matrix")
+	flag.Parse()
+
+	var records []DailyRecord
+	var diff *Diff
+	var err error
+
+	if *incremental {
+		store, serr := statestore.Open(*statePath)
+		if serr != nil {
+			log.Fatal(serr)
+		}
+		defer store.Close()
+
+		var d Diff
+		records, d, err = scrapeDataIncremental(store)
+		diff = &d
+	} else {
+		records, err = scrapeData()
+	}
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -27,10 +70,25 @@ func main() {
 	// Generate summaries
 	summaries := generateSampledSummaries(records)
 
+	if *sampling != "" {
+		strategy, err := parseSamplingFlag(*sampling)
+		if err != nil {
+			log.Fatal(err)
+		}
+		custom := generateSampledSummary(sortedByDate(records), strategy)
+		summaries.Custom = &custom
+	}
+
+	costComparison, err := buildCostComparison(summaries.EveryDay, *monthlyPuts, *monthlyGets)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	// Create export structure
 	export := DataExport{
-		Summaries: summaries,
-		Records:   records,
+		Summaries:      summaries,
+		Records:        records,
+		CostComparison: costComparison,
 	}
 
 	// Convert to JSON
@@ -40,8 +98,8 @@ func main() {
 	}
 
 	// Write to file
-	filename := fmt.Sprintf("data/output/dsa_data_export_%s.json",
-		time.Now().Format("2006-01-02_15-04-05"))
+	timestamp := time.Now().Format("2006-01-02_15-04-05")
+	filename := fmt.Sprintf("data/output/dsa_data_export_%s.json", timestamp)
 
 	err = os.WriteFile(filename, jsonData, 0644)
 	if err != nil {
@@ -49,4 +107,26 @@ func main() {
 	}
 
 	fmt.Printf("Data exported to %s\n", filename)
+
+	if diff != nil {
+		if err := writeDiffReport(diff, timestamp); err != nil {
+			log.Fatal("Error writing diff report:", err)
+		}
+	}
+}
+
+func writeDiffReport(diff *Diff, timestamp string) error {
+	diffData, err := json.MarshalIndent(diff, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling diff report: %v", err)
+	}
+
+	filename := fmt.Sprintf("data/output/dsa_diff_%s.json", timestamp)
+	if err := os.WriteFile(filename, diffData, 0644); err != nil {
+		return err
+	}
+
+	fmt.Printf("Diff report (added=%d changed=%d removed=%d) written to %s\n",
+		len(diff.Added), len(diff.Changed), len(diff.Removed), filename)
+	return nil
 }
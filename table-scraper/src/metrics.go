@@ -0,0 +1,61 @@
+package main
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// metrics holds the Prometheus collectors exposed on /metrics. They're
+// kept on their own registry (rather than the global default one) so the
+// daemon doesn't also publish the Go runtime/process collectors baked into
+// prometheus.DefaultRegisterer.
+type metrics struct {
+	registry                   *prometheus.Registry
+	statementsOfReasonsTotal   *prometheus.GaugeVec
+	fullCSVBytes               *prometheus.GaugeVec
+	scrapeLastSuccessTimestamp prometheus.Gauge
+	scrapeErrorsTotal          prometheus.Counter
+}
+
+func newMetrics() *metrics {
+	reg := prometheus.NewRegistry()
+
+	m := &metrics{
+		registry: reg,
+		statementsOfReasonsTotal: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "dsa_statements_of_reasons_total",
+			Help: "Statements of reasons reported for a given day's archive.",
+		}, []string{"date"}),
+		fullCSVBytes: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "dsa_full_csv_bytes",
+			Help: "Size in bytes of the full CSV archive for a given day.",
+		}, []string{"date"}),
+		scrapeLastSuccessTimestamp: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "dsa_scrape_last_success_timestamp",
+			Help: "Unix timestamp of the last successful scrape.",
+		}),
+		scrapeErrorsTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "dsa_scrape_errors_total",
+			Help: "Number of scrape attempts that failed.",
+		}),
+	}
+
+	reg.MustRegister(m.statementsOfReasonsTotal, m.fullCSVBytes, m.scrapeLastSuccessTimestamp, m.scrapeErrorsTotal)
+	return m
+}
+
+// observe publishes one gauge sample per record plus the scrape success
+// timestamp. It replaces rather than accumulates, since a re-scrape
+// supersedes the previous snapshot.
+func (m *metrics) observe(records []DailyRecord, scrapedAt time.Time) {
+	m.statementsOfReasonsTotal.Reset()
+	m.fullCSVBytes.Reset()
+
+	for _, r := range records {
+		m.statementsOfReasonsTotal.WithLabelValues(r.Date).Set(float64(r.StatementsOfReasons))
+		m.fullCSVBytes.WithLabelValues(r.Date).Set(r.FullCSVSizeMB * 1024 * 1024)
+	}
+
+	m.scrapeLastSuccessTimestamp.Set(float64(scrapedAt.Unix()))
+}
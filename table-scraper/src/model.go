@@ -1,5 +1,7 @@
 package main
 
+import "github.com/o2ba/dsa-data-analysis/table-scraper/costmodel"
+
 type DailyRecord struct {
 	Date                string  `json:"date"`
 	StatementsOfReasons int64   `json:"statements_of_reasons"`
@@ -14,24 +16,38 @@ type DailyRecord struct {
 }
 
 type Summary struct {
-	TotalRecords                     int            `json:"total_records"`
-	TotalStatements                  int64          `json:"total_statements"`
-	TotalFullCSVSizeTB               float64        `json:"total_full_csv_size_tb"`
-	TotalFullZipSizeTB               float64        `json:"total_full_zip_size_tb"`
-	TotalFullParquetConservativeSize float64        `json:"total_full_parquet_conservative_size"`
-	TotalFullParquetAggressiveSize   float64        `json:"total_full_parquet_aggressive_size"`
-	DateRange                        string         `json:"date_range"`
-	ScrapedAt                        string         `json:"scraped_at"`
-	SamplingStrategy                 string         `json:"sampling_strategy,omitempty"`
-	S3StandardCosts                  S3StorageCosts `json:"eu_central_s3_standard_storage_costs,omitempty"`
-	S3DeepGlacierCosts               S3StorageCosts `json:"eu_central_s3_deep_glacier_storage_costs,omitempty"`
+	TotalRecords                     int     `json:"total_records"`
+	TotalStatements                  int64   `json:"total_statements"`
+	TotalFullCSVSizeTB               float64 `json:"total_full_csv_size_tb"`
+	TotalFullZipSizeTB               float64 `json:"total_full_zip_size_tb"`
+	TotalFullParquetConservativeSize float64 `json:"total_full_parquet_conservative_size"`
+	TotalFullParquetAggressiveSize   float64 `json:"total_full_parquet_aggressive_size"`
+	DateRange                        string  `json:"date_range"`
+	ScrapedAt                        string  `json:"scraped_at"`
+	SamplingStrategy                 string  `json:"sampling_strategy,omitempty"`
+	// ParquetSizeSource is "measured" once a `convert` run has overlaid
+	// real Parquet sizes from converter.StatsFile, otherwise "estimated"
+	// (the parquetReductionFactor* guess).
+	ParquetSizeSource              string           `json:"parquet_size_source,omitempty"`
+	MeasuredParquetBytesByPlatform map[string]int64 `json:"measured_parquet_bytes_by_platform,omitempty"`
+	// BootstrapCI is only set when Summary was produced by BootstrapStrategy.
+	BootstrapCI *BootstrapCI `json:"bootstrap_ci,omitempty"`
+}
+
+// ConfidenceInterval is a 95% bootstrap confidence interval around a point
+// estimate's mean.
+type ConfidenceInterval struct {
+	Mean  float64 `json:"mean"`
+	Lower float64 `json:"lower_95"`
+	Upper float64 `json:"upper_95"`
 }
 
-type S3StorageCosts struct {
-	StorageFullCSV             float64 `json:"s3_storage_full_csv"`
-	StorageFullZip             float64 `json:"s3_storage_full_zip"`
-	StorageParquetConservative float64 `json:"s3_storage_parquet_conservative"`
-	StorageParquetAggressive   float64 `json:"s3_storage_parquet_aggressive"`
+// BootstrapCI reports the uncertainty BootstrapStrategy found in the
+// headline storage-cost inputs.
+type BootstrapCI struct {
+	TotalStatements    ConfidenceInterval `json:"total_statements"`
+	TotalFullCSVSizeTB ConfidenceInterval `json:"total_full_csv_size_tb"`
+	TotalFullZipSizeTB ConfidenceInterval `json:"total_full_zip_size_tb"`
 }
 
 type SampledSummaries struct {
@@ -39,9 +55,17 @@ type SampledSummaries struct {
 	Every2Days Summary `json:"every_2_days"`
 	Every4Days Summary `json:"every_4_days"`
 	Every6Days Summary `json:"every_6_days"`
+	// Custom holds the result of whichever SamplingStrategy the --sampling
+	// flag selected, if any.
+	Custom *Summary `json:"custom,omitempty"`
 }
 
 type DataExport struct {
 	Summaries SampledSummaries `json:"summaries"`
 	Records   []DailyRecord    `json:"records"`
+	// CostComparison projects storage, request, and egress costs for
+	// Summaries.EveryDay's dataset size across every built-in costmodel
+	// Provider/tier, so readers can pick the cheapest option for their
+	// access pattern.
+	CostComparison []costmodel.Estimate `json:"cost_comparison,omitempty"`
 }
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SamplingStrategy picks a subset of a (date-sorted) slice of records and
+// turns it into a Summary, so storage-cost projections can be generated
+// from something other than "every day".
+type SamplingStrategy interface {
+	// Name is recorded on the resulting Summary.SamplingStrategy.
+	Name() string
+	// Summarize receives records sorted by Date ascending.
+	Summarize(sorted []DailyRecord) Summary
+}
+
+// FixedIntervalStrategy samples every Interval-th day starting from the
+// first day in the range. This is the original behavior of
+// generateSampledSummary.
+type FixedIntervalStrategy struct {
+	Interval int
+}
+
+func (s FixedIntervalStrategy) Name() string {
+	return fmt.Sprintf("Every %d days from first day", s.Interval)
+}
+
+func (s FixedIntervalStrategy) Summarize(sorted []DailyRecord) Summary {
+	var sampled []DailyRecord
+	for i := 0; i < len(sorted); i += s.Interval {
+		sampled = append(sampled, sorted[i])
+	}
+	return generateSummary(sampled)
+}
+
+// ReservoirStrategy draws a uniform random sample of size K using
+// reservoir sampling, so every day has an equal chance of being included
+// regardless of how many days there are in total.
+type ReservoirStrategy struct {
+	K   int
+	Rng *rand.Rand
+}
+
+func (s ReservoirStrategy) Name() string {
+	return fmt.Sprintf("Reservoir sample of %d days", s.K)
+}
+
+func (s ReservoirStrategy) Summarize(sorted []DailyRecord) Summary {
+	rng := s.Rng
+	if rng == nil {
+		rng = rand.New(rand.NewSource(1))
+	}
+
+	k := s.K
+	if k > len(sorted) {
+		k = len(sorted)
+	}
+
+	reservoir := make([]DailyRecord, k)
+	copy(reservoir, sorted[:k])
+
+	for i := k; i < len(sorted); i++ {
+		j := rng.Intn(i + 1)
+		if j < k {
+			reservoir[j] = sorted[i]
+		}
+	}
+
+	sort.Slice(reservoir, func(i, j int) bool { return reservoir[i].Date < reservoir[j].Date })
+	return generateSummary(reservoir)
+}
+
+// StratifiedByISOWeekStrategy buckets records by ISO week and picks one
+// representative record (the median by date) from each week, so the
+// sample covers the full date range evenly instead of clustering around
+// whichever days a fixed interval happens to land on.
+type StratifiedByISOWeekStrategy struct{}
+
+func (s StratifiedByISOWeekStrategy) Name() string {
+	return "Stratified sample, one day per ISO week"
+}
+
+func (s StratifiedByISOWeekStrategy) Summarize(sorted []DailyRecord) Summary {
+	type weekKey struct {
+		year int
+		week int
+	}
+
+	byWeek := map[weekKey][]DailyRecord{}
+	var order []weekKey
+
+	for _, r := range sorted {
+		t, err := time.Parse("2006-01-02", r.Date)
+		if err != nil {
+			continue
+		}
+		year, week := t.ISOWeek()
+		key := weekKey{year, week}
+		if _, ok := byWeek[key]; !ok {
+			order = append(order, key)
+		}
+		byWeek[key] = append(byWeek[key], r)
+	}
+
+	sampled := make([]DailyRecord, 0, len(order))
+	for _, key := range order {
+		week := byWeek[key]
+		sampled = append(sampled, week[len(week)/2])
+	}
+
+	return generateSummary(sampled)
+}
+
+// BootstrapStrategy resamples the full record set with replacement
+// Iterations times, reporting the mean and 95% confidence interval of
+// TotalStatements and the total CSV/zip sizes rather than a single point
+// estimate.
+type BootstrapStrategy struct {
+	Iterations int
+	Rng        *rand.Rand
+}
+
+func (s BootstrapStrategy) Name() string {
+	return fmt.Sprintf("Bootstrap resample, %d iterations", s.Iterations)
+}
+
+func (s BootstrapStrategy) Summarize(sorted []DailyRecord) Summary {
+	rng := s.Rng
+	if rng == nil {
+		rng = rand.New(rand.NewSource(1))
+	}
+
+	n := len(sorted)
+	statements := make([]float64, s.Iterations)
+	fullCSV := make([]float64, s.Iterations)
+	fullZip := make([]float64, s.Iterations)
+
+	for i := 0; i < s.Iterations; i++ {
+		var sumStatements int64
+		var sumCSV, sumZip float64
+		for j := 0; j < n; j++ {
+			r := sorted[rng.Intn(n)]
+			sumStatements += r.StatementsOfReasons
+			sumCSV += r.FullCSVSizeMB
+			sumZip += r.FullZipSizeMB
+		}
+		statements[i] = float64(sumStatements)
+		fullCSV[i] = mbToTb(sumCSV)
+		fullZip[i] = mbToTb(sumZip)
+	}
+
+	summary := generateSummary(sorted)
+	summary.BootstrapCI = &BootstrapCI{
+		TotalStatements:    confidenceInterval95(statements),
+		TotalFullCSVSizeTB: confidenceInterval95(fullCSV),
+		TotalFullZipSizeTB: confidenceInterval95(fullZip),
+	}
+	return summary
+}
+
+// confidenceInterval95 reports the mean and the 2.5th/97.5th percentiles
+// of samples, i.e. a 95% percentile bootstrap confidence interval.
+func confidenceInterval95(samples []float64) ConfidenceInterval {
+	if len(samples) == 0 {
+		return ConfidenceInterval{}
+	}
+
+	sorted := make([]float64, len(samples))
+	copy(sorted, samples)
+	sort.Float64s(sorted)
+
+	var sum float64
+	for _, v := range sorted {
+		sum += v
+	}
+	mean := sum / float64(len(sorted))
+
+	lowerIdx := int(math.Floor(0.025 * float64(len(sorted)-1)))
+	upperIdx := int(math.Ceil(0.975 * float64(len(sorted)-1)))
+
+	return ConfidenceInterval{
+		Mean:  mean,
+		Lower: sorted[lowerIdx],
+		Upper: sorted[upperIdx],
+	}
+}
+
+// parseSamplingFlag turns the --sampling flag value into a SamplingStrategy.
+// Accepted forms: "fixed:N", "reservoir:K", "stratified", "bootstrap:N".
+func parseSamplingFlag(spec string) (SamplingStrategy, error) {
+	name, param, _ := strings.Cut(spec, ":")
+
+	switch name {
+	case "", "fixed":
+		interval := 1
+		if param != "" {
+			n, err := strconv.Atoi(param)
+			if err != nil {
+				return nil, fmt.Errorf("--sampling: invalid fixed interval %q: %v", param, err)
+			}
+			interval = n
+		}
+		if interval < 1 {
+			return nil, fmt.Errorf("--sampling: fixed interval must be >= 1, got %d", interval)
+		}
+		return FixedIntervalStrategy{Interval: interval}, nil
+
+	case "reservoir":
+		k, err := strconv.Atoi(param)
+		if err != nil {
+			return nil, fmt.Errorf("--sampling: reservoir requires a sample size, e.g. reservoir:50: %v", err)
+		}
+		if k < 1 {
+			return nil, fmt.Errorf("--sampling: reservoir sample size must be >= 1, got %d", k)
+		}
+		return ReservoirStrategy{K: k}, nil
+
+	case "stratified":
+		return StratifiedByISOWeekStrategy{}, nil
+
+	case "bootstrap":
+		iterations := 1000
+		if param != "" {
+			n, err := strconv.Atoi(param)
+			if err != nil {
+				return nil, fmt.Errorf("--sampling: invalid bootstrap iteration count %q: %v", param, err)
+			}
+			iterations = n
+		}
+		if iterations < 1 {
+			return nil, fmt.Errorf("--sampling: bootstrap iteration count must be >= 1, got %d", iterations)
+		}
+		return BootstrapStrategy{Iterations: iterations}, nil
+
+	default:
+		return nil, fmt.Errorf("--sampling: unknown strategy %q", name)
+	}
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"log"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/robfig/cron/v3"
+)
+
+// daemon holds the most recently scraped data and health/metrics state for
+// the serve subcommand's HTTP handlers.
+type daemon struct {
+	mu          sync.RWMutex
+	records     []DailyRecord
+	summaries   SampledSummaries
+	lastSuccess time.Time
+	lastErr     error
+
+	metrics *metrics
+	log     *slog.Logger
+}
+
+// runServe implements the `serve` subcommand: scrape on a cron schedule
+// and publish the results over HTTP until the process is signaled to stop.
+func runServe(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", envOr("DSA_SERVE_ADDR", ":8080"), "address to listen on")
+	schedule := fs.String("schedule", envOr("DSA_SCRAPE_SCHEDULE", "@every 1h"), "cron schedule for re-scraping (robfig/cron syntax)")
+	if err := fs.Parse(args); err != nil {
+		log.Fatal(err)
+	}
+
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	d := &daemon{
+		metrics: newMetrics(),
+		log:     logger,
+	}
+
+	c := cron.New()
+	if _, err := c.AddFunc(*schedule, d.refresh); err != nil {
+		logger.Error("invalid schedule", "schedule", *schedule, "error", err)
+		os.Exit(1)
+	}
+	c.Start()
+	defer c.Stop()
+
+	// Populate an initial snapshot before serving traffic.
+	d.refresh()
+
+	dashboard, err := dashboardHandler()
+	if err != nil {
+		logger.Error("loading dashboard assets", "error", err)
+		os.Exit(1)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", d.handleHealthz)
+	mux.HandleFunc("/records", d.handleRecords)
+	mux.HandleFunc("/records/", d.handleRecordByDate)
+	mux.HandleFunc("/summary", d.handleSummary)
+	mux.Handle("/metrics", promhttp.HandlerFor(d.metrics.registry, promhttp.HandlerOpts{}))
+	mux.Handle("/", dashboard)
+
+	srv := &http.Server{Addr: *addr, Handler: mux}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		logger.Info("listening", "addr", *addr, "schedule", *schedule)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("server error", "error", err)
+			stop()
+		}
+	}()
+
+	<-ctx.Done()
+	logger.Info("shutting down")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		logger.Error("graceful shutdown failed", "error", err)
+	}
+}
+
+// refresh re-scrapes the DSA portal and publishes the result, recording
+// failures on the daemon and on the dsa_scrape_errors_total counter rather than
+// crashing the process.
+func (d *daemon) refresh() {
+	records, err := scrapeData()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if err != nil {
+		d.lastErr = err
+		d.metrics.scrapeErrorsTotal.Inc()
+		d.log.Error("scrape failed", "error", err)
+		return
+	}
+
+	d.records = records
+	d.summaries = generateSampledSummaries(records)
+	d.lastSuccess = time.Now().UTC()
+	d.lastErr = nil
+	d.metrics.observe(records, d.lastSuccess)
+	d.log.Info("scrape succeeded", "records", len(records))
+}
+
+func (d *daemon) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	d.mu.RLock()
+	ok := !d.lastSuccess.IsZero()
+	d.mu.RUnlock()
+
+	status := http.StatusOK
+	if !ok {
+		status = http.StatusServiceUnavailable
+	}
+	writeJSON(w, status, map[string]any{"ok": ok})
+}
+
+func (d *daemon) handleRecords(w http.ResponseWriter, r *http.Request) {
+	d.mu.RLock()
+	records := d.records
+	d.mu.RUnlock()
+	writeJSON(w, http.StatusOK, records)
+}
+
+func (d *daemon) handleRecordByDate(w http.ResponseWriter, r *http.Request) {
+	date := strings.TrimPrefix(r.URL.Path, "/records/")
+	if date == "" {
+		d.handleRecords(w, r)
+		return
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	for _, rec := range d.records {
+		if rec.Date == date {
+			writeJSON(w, http.StatusOK, rec)
+			return
+		}
+	}
+	writeJSON(w, http.StatusNotFound, map[string]string{"error": "no record for date " + date})
+}
+
+func (d *daemon) handleSummary(w http.ResponseWriter, r *http.Request) {
+	interval, err := strconv.Atoi(r.URL.Query().Get("interval"))
+	if err != nil {
+		interval = 1
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	var summary Summary
+	switch interval {
+	case 2:
+		summary = d.summaries.Every2Days
+	case 4:
+		summary = d.summaries.Every4Days
+	case 6:
+		summary = d.summaries.Every6Days
+	default:
+		summary = d.summaries.EveryDay
+	}
+	writeJSON(w, http.StatusOK, summary)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"sort"
 	"time"
 )
 
@@ -48,22 +49,9 @@ func generateSummary(records []DailyRecord) Summary {
 		TotalFullZipSizeTB:               totalFullZipSizeTB,
 		TotalFullParquetConservativeSize: totalFullParquetConservativeSize,
 		TotalFullParquetAggressiveSize:   totalFullParquetAggressiveSize,
-		S3StandardCosts: getS3StorageCosts(
-			totalFullCSVSizeTB,
-			totalFullZipSizeTB,
-			euCentralS3StroageStandardPerGB,
-			parquetReductionFactorConservative,
-			parquetReductionFactorAggressive,
-		),
-		S3DeepGlacierCosts: getS3StorageCosts(
-			totalFullCSVSizeTB,
-			totalFullZipSizeTB,
-			euCentralS3StroageGlacierDeepArchivePerGB,
-			parquetReductionFactorConservative,
-			parquetReductionFactorAggressive,
-		),
-		DateRange: dateRange,
-		ScrapedAt: time.Now().UTC().Format(time.RFC3339),
+		DateRange:                        dateRange,
+		ScrapedAt:                        time.Now().UTC().Format(time.RFC3339),
+		ParquetSizeSource:                "estimated",
 	}
 }
 
@@ -71,43 +59,38 @@ func mbToTb(mb float64) float64 {
 	return mb / 1024 / 1024
 }
 
+// generateSampledSummaries sorts records by date once and shares that
+// sorted slice across every fixed-interval sampling variant, so the whole
+// computation is O(n log n) instead of re-sorting (or bubble-sorting) once
+// per variant.
 func generateSampledSummaries(records []DailyRecord) SampledSummaries {
+	sorted := sortedByDate(records)
+
 	return SampledSummaries{
-		EveryDay: generateSampledSummary(records, 1),
+		EveryDay: generateSampledSummary(sorted, FixedIntervalStrategy{Interval: 1}),
 		// N should not be divisible by 7 so we don't sample the same weekday
-		Every2Days: generateSampledSummary(records, 2),
-		Every4Days: generateSampledSummary(records, 4),
-		Every6Days: generateSampledSummary(records, 6),
+		Every2Days: generateSampledSummary(sorted, FixedIntervalStrategy{Interval: 2}),
+		Every4Days: generateSampledSummary(sorted, FixedIntervalStrategy{Interval: 4}),
+		Every6Days: generateSampledSummary(sorted, FixedIntervalStrategy{Interval: 6}),
 	}
 }
 
-func generateSampledSummary(records []DailyRecord, interval int) Summary {
-	if len(records) == 0 {
-		return Summary{}
-	}
-
-	// Sort records by date to ensure consistent sampling
-	sortedRecords := make([]DailyRecord, len(records))
-	copy(sortedRecords, records)
-
-	// Simple sort by date string (works for YYYY-MM-DD format)
-	for i := 0; i < len(sortedRecords)-1; i++ {
-		for j := i + 1; j < len(sortedRecords); j++ {
-			if sortedRecords[i].Date > sortedRecords[j].Date {
-				sortedRecords[i], sortedRecords[j] = sortedRecords[j], sortedRecords[i]
-			}
-		}
-	}
+func sortedByDate(records []DailyRecord) []DailyRecord {
+	sorted := make([]DailyRecord, len(records))
+	copy(sorted, records)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Date < sorted[j].Date })
+	return sorted
+}
 
-	// Sample every N days starting from the first day (index 0)
-	var sampledRecords []DailyRecord
-	for i := 0; i < len(sortedRecords); i += interval {
-		sampledRecords = append(sampledRecords, sortedRecords[i])
+// generateSampledSummary applies a SamplingStrategy to an already-sorted
+// slice of records and summarizes the result. sorted must be sorted by
+// Date ascending, as produced by sortedByDate.
+func generateSampledSummary(sorted []DailyRecord, strategy SamplingStrategy) Summary {
+	if len(sorted) == 0 {
+		return Summary{}
 	}
 
-	// Generate summary for sampled records
-	summary := generateSummary(sampledRecords)
-	summary.SamplingStrategy = fmt.Sprintf("Every %d days from first day", interval)
-
+	summary := strategy.Summarize(sorted)
+	summary.SamplingStrategy = strategy.Name()
 	return summary
 }
@@ -0,0 +1,93 @@
+// Package statestore persists the last-seen full_sha1_url and a row hash
+// per scraped record (not the archive's SHA1 checksum itself) so incremental scrapes can tell which pages of the DSA archive
+// listing have changed since the last run without re-reading all of them.
+package statestore
+
+import (
+	"encoding/json"
+
+	"go.etcd.io/bbolt"
+)
+
+var bucketName = []byte("records")
+
+// Entry is what we remember about a single (date, full_sha1_url) key.
+type Entry struct {
+	Date string `json:"date"`
+	// FullSha1URL is the record's full_sha1_url at the time it was last
+	// seen, not a computed checksum — the scrape loop only reads the DSA
+	// portal's listing pages, it never downloads archives to hash them.
+	FullSha1URL string `json:"full_sha1_url"`
+	RowHash     string `json:"row_hash"`
+	LastSeenAt  string `json:"last_seen_at"`
+}
+
+// Store is a BoltDB-backed key/value store of Entries.
+type Store struct {
+	db *bbolt.DB
+}
+
+// Open opens (creating if necessary) the BoltDB file at path and ensures
+// its records bucket exists.
+func Open(path string) (*Store, error) {
+	db, err := bbolt.Open(path, 0o644, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(bucketName)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Close closes the underlying BoltDB file.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Get looks up key, returning (entry, true, nil) if present.
+func (s *Store) Get(key string) (Entry, bool, error) {
+	var entry Entry
+	var found bool
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		v := tx.Bucket(bucketName).Get([]byte(key))
+		if v == nil {
+			return nil
+		}
+		found = true
+		return json.Unmarshal(v, &entry)
+	})
+	return entry, found, err
+}
+
+// Put records or replaces the entry for key.
+func (s *Store) Put(key string, entry Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(bucketName).Put([]byte(key), data)
+	})
+}
+
+// ForEach visits every stored key/entry pair in key order.
+func (s *Store) ForEach(fn func(key string, entry Entry) error) error {
+	return s.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(bucketName).ForEach(func(k, v []byte) error {
+			var e Entry
+			if err := json.Unmarshal(v, &e); err != nil {
+				return err
+			}
+			return fn(string(k), e)
+		})
+	})
+}